@@ -0,0 +1,91 @@
+package apperr
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
+)
+
+// machine readable codes sent alongside the error message
+const (
+	CodeBadRequest   = "BAD_REQUEST"
+	CodeValidation   = "VALIDATION_FAILED"
+	CodeUnauthorized = "UNAUTHORIZED"
+	CodeForbidden    = "FORBIDDEN"
+	CodeNotFound     = "NOT_FOUND"
+	CodeConflict     = "CONFLICT"
+	CodeInternal     = "INTERNAL_SERVER_ERROR"
+	CodeUnavailable  = "SERVICE_UNAVAILABLE"
+)
+
+// Error is an error with the HTTP status and code to reply with.
+type Error struct {
+	Status  int
+	Code    string
+	Message string
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Is matches errors by code, so errors.Is(err, apperr.ErrNotFound) holds for
+// any NotFound(...) error.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code
+}
+
+var (
+	ErrBadRequest   = BadRequest("Bad Request")
+	ErrValidation   = Validation("Validation failed")
+	ErrUnauthorized = Unauthorized("Unauthorized")
+	ErrForbidden    = Forbidden("Forbidden")
+	ErrNotFound     = NotFound("Not Found")
+	ErrConflict     = Conflict("Conflict")
+	ErrInternal     = Internal("Internal Server Error")
+	ErrUnavailable  = Unavailable("Service Unavailable")
+)
+
+func BadRequest(message string) *Error {
+	return &Error{fiber.StatusBadRequest, CodeBadRequest, message}
+}
+
+func Validation(message string) *Error {
+	return &Error{fiber.StatusBadRequest, CodeValidation, message}
+}
+
+func Unauthorized(message string) *Error {
+	return &Error{fiber.StatusUnauthorized, CodeUnauthorized, message}
+}
+
+func Forbidden(message string) *Error {
+	return &Error{fiber.StatusForbidden, CodeForbidden, message}
+}
+
+func NotFound(message string) *Error {
+	return &Error{fiber.StatusNotFound, CodeNotFound, message}
+}
+
+func Conflict(message string) *Error {
+	return &Error{fiber.StatusConflict, CodeConflict, message}
+}
+
+func Internal(message string) *Error {
+	return &Error{fiber.StatusInternalServerError, CodeInternal, message}
+}
+
+func Unavailable(message string) *Error {
+	return &Error{fiber.StatusServiceUnavailable, CodeUnavailable, message}
+}
+
+// FromStatus wraps a plain status, e.g. from a *fiber.Error, deriving the
+// code from the status text ("Too Many Requests" -> TOO_MANY_REQUESTS).
+func FromStatus(status int, message string) *Error {
+	code := strings.ToUpper(strings.ReplaceAll(utils.StatusMessage(status), " ", "_"))
+	if code == "" {
+		code = CodeInternal
+	}
+	return &Error{status, code, message}
+}
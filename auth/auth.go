@@ -1,10 +1,35 @@
 package auth
 
 import (
+	"fmt"
+
 	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 func GenUuid() string {
 	uuid := uuid.New()
 	return uuid.String()
 }
+
+// newUUID is swapped out in tests to force collisions
+var newUUID = GenUuid
+
+// GenUniqueUUID returns a UUID not already present in table.column,
+// regenerating on the (very unlikely) collision.
+func GenUniqueUUID(db *gorm.DB, table, column string) (string, error) {
+	for i := 0; i < 5; i++ {
+		id := newUUID()
+
+		var count int64
+		err := db.Table(table).Where(clause.Eq{Column: clause.Column{Name: column}, Value: id}).Count(&count).Error
+		if err != nil {
+			return "", err
+		}
+		if count == 0 {
+			return id, nil
+		}
+	}
+	return "", fmt.Errorf("auth: no unique uuid for %s.%s after 5 attempts", table, column)
+}
@@ -0,0 +1,71 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type token struct {
+	Key string
+}
+
+func TestGenUuid(t *testing.T) {
+	if _, err := uuid.Parse(GenUuid()); err != nil {
+		t.Errorf("GenUuid() is not a uuid: %v", err)
+	}
+}
+
+func TestGenUniqueUUIDSkipsCollision(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	if err := db.AutoMigrate(&token{}); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+
+	taken := "11111111-1111-1111-1111-111111111111"
+	fresh := "22222222-2222-2222-2222-222222222222"
+	if err := db.Create(&token{Key: taken}).Error; err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+
+	candidates := []string{taken, fresh}
+	newUUID = func() string {
+		id := candidates[0]
+		candidates = candidates[1:]
+		return id
+	}
+	t.Cleanup(func() { newUUID = GenUuid })
+
+	got, err := GenUniqueUUID(db, "tokens", "key")
+	if err != nil {
+		t.Fatalf("GenUniqueUUID() error = %v", err)
+	}
+	if got != fresh {
+		t.Errorf("GenUniqueUUID() = %q, want %q", got, fresh)
+	}
+}
+
+func TestGenUniqueUUIDGivesUp(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	if err := db.AutoMigrate(&token{}); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+
+	taken := "11111111-1111-1111-1111-111111111111"
+	db.Create(&token{Key: taken})
+	newUUID = func() string { return taken }
+	t.Cleanup(func() { newUUID = GenUuid })
+
+	if _, err := GenUniqueUUID(db, "tokens", "key"); err == nil {
+		t.Error("GenUniqueUUID() succeeded with every candidate taken, want error")
+	}
+}
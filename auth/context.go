@@ -0,0 +1,41 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+var ErrNoUserID = errors.New("auth: no user_id in context")
+
+// UserIDFromContext reads user_id from locals. JWT numeric claims decode as
+// float64, so that and string/int forms are converted to uint.
+func UserIDFromContext(c *fiber.Ctx) (uint, error) {
+	switch v := c.Locals("user_id").(type) {
+	case nil:
+		return 0, ErrNoUserID
+	case uint:
+		return v, nil
+	case int:
+		if v < 0 {
+			return 0, fmt.Errorf("auth: invalid user_id %d", v)
+		}
+		return uint(v), nil
+	case float64:
+		if v < 0 || v != math.Trunc(v) || v > math.MaxUint32 {
+			return 0, fmt.Errorf("auth: invalid user_id %v", v)
+		}
+		return uint(v), nil
+	case string:
+		id, err := strconv.ParseUint(v, 10, 0)
+		if err != nil {
+			return 0, fmt.Errorf("auth: invalid user_id %q", v)
+		}
+		return uint(id), nil
+	default:
+		return 0, fmt.Errorf("auth: unexpected user_id type %T", v)
+	}
+}
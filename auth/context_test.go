@@ -0,0 +1,58 @@
+package auth
+
+import (
+	"errors"
+	"math"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestUserIDFromContext(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   interface{}
+		want    uint
+		wantErr bool
+	}{
+		{"jwt float64", float64(42), 42, false},
+		{"string", "42", 42, false},
+		{"uint", uint(42), 42, false},
+		{"int", 42, 42, false},
+		{"missing", nil, 0, true},
+		{"fractional float64", 4.2, 0, true},
+		{"negative float64", float64(-1), 0, true},
+		{"huge float64", math.MaxFloat64, 0, true},
+		{"non numeric string", "abc", 0, true},
+		{"wrong type", true, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got uint
+			var err error
+			app := fiber.New()
+			app.Get("/", func(c *fiber.Ctx) error {
+				if tt.value != nil {
+					c.Locals("user_id", tt.value)
+				}
+				got, err = UserIDFromContext(c)
+				return nil
+			})
+			if _, testErr := app.Test(httptest.NewRequest("GET", "/", nil)); testErr != nil {
+				t.Fatal(testErr)
+			}
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("UserIDFromContext() error = %v, want error %t", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("UserIDFromContext() = %d, want %d", got, tt.want)
+			}
+			if tt.value == nil && !errors.Is(err, ErrNoUserID) {
+				t.Errorf("missing user_id error = %v, want ErrNoUserID", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,344 @@
+package config
+
+import (
+	"GO-X/features"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"gorm.io/gorm/logger"
+)
+
+// Config holds everything read from the environment at startup.
+type Config struct {
+	Port       string
+	Debug      bool
+	ForceHTTPS bool
+	// startup state, can be flipped at runtime
+	Maintenance bool
+	// 406 for clients that don't accept json
+	StrictAccept bool
+	// zero disables slow request logging
+	SlowRequest time.Duration
+
+	BodyLimit    int
+	ReadTimeout  time.Duration
+	WriteTimeout time.Duration
+	IdleTimeout  time.Duration
+
+	SecurityHeaders bool
+	FrameOptions    string
+	ReferrerPolicy  string
+	// unset by default, /docs pulls swagger ui from a cdn
+	ContentSecurityPolicy string
+
+	// empty means every origin is allowed
+	CorsOrigins   []string
+	CompressLevel int
+	RateLimit     int
+	RateWindow    time.Duration
+	// header carrying the client ip, e.g. X-Real-IP, only read from
+	// TrustedProxies. Empty uses the socket address
+	ProxyHeader    string
+	TrustedProxies []string
+
+	// empty host logs mail instead of sending it
+	SMTPHost     string
+	SMTPPort     string
+	SMTPUsername string
+	SMTPPassword string
+	SMTPFrom     string
+
+	// FEATURE_<NAME> flags keyed by lower-case name
+	Features features.Flags
+
+	// empty when no DB_* is set, the app then runs without a database
+	DBDriver string
+	// mysql dsn, or the sqlite file path
+	DBDSN string
+	// zero disables slow query logging
+	DBSlowThreshold time.Duration
+	DBLogLevel      logger.LogLevel
+}
+
+// Load reads the environment into a Config, applying defaults for unset
+// values and rejecting values that don't parse.
+func Load() (*Config, error) {
+	var err error
+	cfg := &Config{
+		Port:                  getString("PORT", "8080"),
+		FrameOptions:          getOptional("X_FRAME_OPTIONS", "DENY"),
+		ReferrerPolicy:        getOptional("REFERRER_POLICY", "no-referrer"),
+		ContentSecurityPolicy: os.Getenv("CONTENT_SECURITY_POLICY"),
+		SMTPHost:              os.Getenv("SMTP_HOST"),
+		SMTPPort:              getString("SMTP_PORT", "587"),
+		SMTPUsername:          os.Getenv("SMTP_USERNAME"),
+		SMTPPassword:          os.Getenv("SMTP_PASSWORD"),
+		SMTPFrom:              os.Getenv("SMTP_FROM"),
+		ProxyHeader:           os.Getenv("PROXY_HEADER"),
+		DBDriver:              os.Getenv("DB_DRIVER"),
+		DBDSN:                 os.Getenv("DB_DSN"),
+	}
+
+	if cfg.Debug, err = getBool("DEBUG", false); err != nil {
+		return nil, err
+	}
+
+	if cfg.ForceHTTPS, err = getBool("FORCE_HTTPS", false); err != nil {
+		return nil, err
+	}
+
+	if cfg.Maintenance, err = getBool("MAINTENANCE_MODE", false); err != nil {
+		return nil, err
+	}
+
+	if cfg.StrictAccept, err = getBool("STRICT_ACCEPT", false); err != nil {
+		return nil, err
+	}
+
+	slowMS, err := getInt("SLOW_REQUEST_MS", 1000)
+	if err != nil {
+		return nil, err
+	}
+	if slowMS < 0 {
+		return nil, fmt.Errorf("config: SLOW_REQUEST_MS can not be negative")
+	}
+	cfg.SlowRequest = time.Duration(slowMS) * time.Millisecond
+
+	if cfg.SecurityHeaders, err = getBool("SECURITY_HEADERS", true); err != nil {
+		return nil, err
+	}
+
+	if cfg.BodyLimit, err = getInt("MAX_BODY_SIZE", 1<<20); err != nil {
+		return nil, err
+	}
+	if cfg.BodyLimit <= 0 {
+		return nil, fmt.Errorf("config: MAX_BODY_SIZE must be positive")
+	}
+
+	if cfg.ReadTimeout, err = getDuration("READ_TIMEOUT", 10*time.Second, time.Second, 5*time.Minute); err != nil {
+		return nil, err
+	}
+	if cfg.WriteTimeout, err = getDuration("WRITE_TIMEOUT", 10*time.Second, time.Second, 5*time.Minute); err != nil {
+		return nil, err
+	}
+	if cfg.IdleTimeout, err = getDuration("IDLE_TIMEOUT", time.Minute, time.Second, 10*time.Minute); err != nil {
+		return nil, err
+	}
+
+	if cfg.CorsOrigins, err = parseOrigins(os.Getenv("CORS_ALLOWED_ORIGINS")); err != nil {
+		return nil, err
+	}
+
+	if cfg.CompressLevel, err = getInt("COMPRESS_LEVEL", 0); err != nil {
+		return nil, err
+	}
+	if cfg.CompressLevel < -1 || cfg.CompressLevel > 2 {
+		return nil, fmt.Errorf("config: COMPRESS_LEVEL must be between -1 and 2")
+	}
+
+	if cfg.RateLimit, err = getInt("RATE_LIMIT", 100); err != nil {
+		return nil, err
+	}
+	if cfg.RateLimit <= 0 {
+		return nil, fmt.Errorf("config: RATE_LIMIT must be positive")
+	}
+	if cfg.RateWindow, err = getDuration("RATE_WINDOW", time.Minute, time.Second, 24*time.Hour); err != nil {
+		return nil, err
+	}
+
+	if cfg.TrustedProxies, err = parseProxies(os.Getenv("TRUSTED_PROXIES")); err != nil {
+		return nil, err
+	}
+	if cfg.ProxyHeader != "" && len(cfg.TrustedProxies) == 0 {
+		return nil, fmt.Errorf("config: TRUSTED_PROXIES is required when PROXY_HEADER is set")
+	}
+
+	if cfg.SMTPHost != "" && cfg.SMTPFrom == "" {
+		return nil, fmt.Errorf("config: SMTP_FROM is required when SMTP_HOST is set")
+	}
+
+	if cfg.Features, err = getFeatures(); err != nil {
+		return nil, err
+	}
+
+	if cfg.DBDriver == "" && (cfg.DBDSN != "" || os.Getenv("DB_NAME") != "") {
+		cfg.DBDriver = "mysql"
+	}
+	switch cfg.DBDriver {
+	case "":
+	case "mysql":
+		if cfg.DBDSN == "" {
+			if cfg.DBDSN, err = mysqlDSN(); err != nil {
+				return nil, err
+			}
+		}
+	case "sqlite":
+		if cfg.DBDSN == "" {
+			cfg.DBDSN = getString("DB_NAME", ":memory:")
+		}
+	default:
+		return nil, fmt.Errorf("config: unsupported DB_DRIVER %q", cfg.DBDriver)
+	}
+
+	if cfg.DBSlowThreshold, err = getDuration("DB_SLOW_THRESHOLD", 200*time.Millisecond, 0, time.Minute); err != nil {
+		return nil, err
+	}
+
+	switch level := getString("DB_LOG_LEVEL", "warn"); level {
+	case "silent":
+		cfg.DBLogLevel = logger.Silent
+	case "error":
+		cfg.DBLogLevel = logger.Error
+	case "warn":
+		cfg.DBLogLevel = logger.Warn
+	case "info":
+		cfg.DBLogLevel = logger.Info
+	default:
+		return nil, fmt.Errorf("config: invalid DB_LOG_LEVEL %q", level)
+	}
+
+	return cfg, nil
+}
+
+// getFeatures reads every FEATURE_<NAME> variable as a bool.
+func getFeatures() (features.Flags, error) {
+	flags := features.Flags{}
+	for _, kv := range os.Environ() {
+		key, _, _ := strings.Cut(kv, "=")
+		name, ok := strings.CutPrefix(key, "FEATURE_")
+		if !ok || name == "" {
+			continue
+		}
+		on, err := getBool(key, false)
+		if err != nil {
+			return nil, err
+		}
+		flags[strings.ToLower(name)] = on
+	}
+	return flags, nil
+}
+
+// mysqlDSN builds a dsn from DB_HOST, DB_USER, DB_PASSWORD and DB_NAME.
+func mysqlDSN() (string, error) {
+	name := os.Getenv("DB_NAME")
+	if name == "" {
+		return "", fmt.Errorf("config: DB_DSN or DB_NAME is required for mysql")
+	}
+
+	dsn := mysql.NewConfig()
+	dsn.Net = "tcp"
+	dsn.Addr = getString("DB_HOST", "127.0.0.1:3306")
+	dsn.User = os.Getenv("DB_USER")
+	dsn.Passwd = os.Getenv("DB_PASSWORD")
+	dsn.DBName = name
+	// bounds the dial, an unreachable host otherwise waits for the os timeout
+	dsn.Timeout = 5 * time.Second
+	dsn.ParseTime = true
+	dsn.Loc = time.Local
+	dsn.Params = map[string]string{"charset": "utf8mb4"}
+	return dsn.FormatDSN(), nil
+}
+
+func getString(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}
+
+// getOptional is like getString, but a variable set to "" stays empty so
+// it can turn a default off.
+func getOptional(key, def string) string {
+	if v, ok := os.LookupEnv(key); ok {
+		return v
+	}
+	return def
+}
+
+func getBool(key string, def bool) (bool, error) {
+	v := os.Getenv(key)
+	if v == "" {
+		return def, nil
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return false, fmt.Errorf("config: invalid %s %q", key, v)
+	}
+	return b, nil
+}
+
+func getInt(key string, def int) (int, error) {
+	v := os.Getenv(key)
+	if v == "" {
+		return def, nil
+	}
+	i, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, fmt.Errorf("config: invalid %s %q", key, v)
+	}
+	return i, nil
+}
+
+// getDuration parses a duration and clamps it to [min, max].
+func getDuration(key string, def, min, max time.Duration) (time.Duration, error) {
+	d := def
+	if v := os.Getenv(key); v != "" {
+		var err error
+		if d, err = time.ParseDuration(v); err != nil {
+			return 0, fmt.Errorf("config: invalid %s %q", key, v)
+		}
+	}
+	if d < min {
+		return min, nil
+	}
+	if d > max {
+		return max, nil
+	}
+	return d, nil
+}
+
+// parseOrigins returns nil (allow all) for an empty value or a lone "*".
+func parseOrigins(raw string) ([]string, error) {
+	if strings.TrimSpace(raw) == "*" {
+		return nil, nil
+	}
+
+	var origins []string
+	for _, origin := range strings.Split(raw, ",") {
+		origin = strings.TrimSpace(origin)
+		if origin == "" {
+			continue
+		}
+		if origin == "*" {
+			return nil, fmt.Errorf("config: CORS_ALLOWED_ORIGINS can't list \"*\" alongside specific origins")
+		}
+		u, err := url.Parse(origin)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || strings.TrimSuffix(u.Path, "/") != "" {
+			return nil, fmt.Errorf("config: invalid CORS origin %q", origin)
+		}
+		origins = append(origins, u.Scheme+"://"+u.Host)
+	}
+	return origins, nil
+}
+
+// parseProxies splits a comma separated list of proxy IPs or CIDR ranges.
+func parseProxies(raw string) ([]string, error) {
+	var proxies []string
+	for _, proxy := range strings.Split(raw, ",") {
+		proxy = strings.TrimSpace(proxy)
+		if proxy == "" {
+			continue
+		}
+		if _, _, err := net.ParseCIDR(proxy); err != nil && net.ParseIP(proxy) == nil {
+			return nil, fmt.Errorf("config: invalid TRUSTED_PROXIES entry %q", proxy)
+		}
+		proxies = append(proxies, proxy)
+	}
+	return proxies, nil
+}
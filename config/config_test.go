@@ -0,0 +1,320 @@
+package config
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+// clearEnv unsets every key Load reads, restoring them after the test.
+func clearEnv(t *testing.T) {
+	t.Helper()
+	for _, key := range []string{
+		"PORT", "DEBUG", "FORCE_HTTPS", "MAINTENANCE_MODE", "STRICT_ACCEPT", "SLOW_REQUEST_MS",
+		"SECURITY_HEADERS", "X_FRAME_OPTIONS", "REFERRER_POLICY", "CONTENT_SECURITY_POLICY",
+		"MAX_BODY_SIZE", "READ_TIMEOUT", "WRITE_TIMEOUT", "IDLE_TIMEOUT",
+		"CORS_ALLOWED_ORIGINS", "COMPRESS_LEVEL", "RATE_LIMIT", "RATE_WINDOW",
+		"PROXY_HEADER", "TRUSTED_PROXIES",
+		"DB_DRIVER", "DB_DSN", "DB_HOST", "DB_USER", "DB_PASSWORD", "DB_NAME",
+		"DB_SLOW_THRESHOLD", "DB_LOG_LEVEL",
+		"SMTP_HOST", "SMTP_PORT", "SMTP_USERNAME", "SMTP_PASSWORD", "SMTP_FROM",
+	} {
+		t.Setenv(key, "")
+		os.Unsetenv(key)
+	}
+	for _, kv := range os.Environ() {
+		if key, _, _ := strings.Cut(kv, "="); strings.HasPrefix(key, "FEATURE_") {
+			t.Setenv(key, "")
+			os.Unsetenv(key)
+		}
+	}
+}
+
+func TestLoadDefaults(t *testing.T) {
+	clearEnv(t)
+	t.Setenv("DB_NAME", "gox")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Port != "8080" {
+		t.Errorf("Port = %q, want 8080", cfg.Port)
+	}
+	if cfg.Debug {
+		t.Error("Debug = true, want false")
+	}
+	if cfg.BodyLimit != 1<<20 {
+		t.Errorf("BodyLimit = %d, want %d", cfg.BodyLimit, 1<<20)
+	}
+	if cfg.RateLimit != 100 || cfg.RateWindow != time.Minute {
+		t.Errorf("rate limit = %d per %s, want 100 per 1m", cfg.RateLimit, cfg.RateWindow)
+	}
+	if len(cfg.CorsOrigins) != 0 {
+		t.Errorf("CorsOrigins = %v, want none", cfg.CorsOrigins)
+	}
+	if cfg.DBDriver != "mysql" {
+		t.Errorf("DBDriver = %q, want mysql", cfg.DBDriver)
+	}
+	if want := "tcp(127.0.0.1:3306)/gox?"; !strings.Contains(cfg.DBDSN, want) {
+		t.Errorf("DBDSN = %q, want it to contain %q", cfg.DBDSN, want)
+	}
+}
+
+func TestLoadMysqlDSN(t *testing.T) {
+	clearEnv(t)
+	t.Setenv("DB_HOST", "db:3307")
+	t.Setenv("DB_USER", "app")
+	t.Setenv("DB_PASSWORD", "secret")
+	t.Setenv("DB_NAME", "gox")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if want := "app:secret@tcp(db:3307)/gox?"; !strings.HasPrefix(cfg.DBDSN, want) || !strings.Contains(cfg.DBDSN, "timeout=5s") {
+		t.Errorf("DBDSN = %q, want prefix %q and a dial timeout", cfg.DBDSN, want)
+	}
+
+	t.Setenv("DB_DSN", "root@tcp(other:3306)/x")
+	if cfg, _ = Load(); cfg.DBDSN != "root@tcp(other:3306)/x" {
+		t.Errorf("DBDSN = %q, want DB_DSN used as is", cfg.DBDSN)
+	}
+}
+
+func TestLoadWithoutDatabase(t *testing.T) {
+	clearEnv(t)
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() with an empty environment error = %v", err)
+	}
+	if cfg.DBDriver != "" || cfg.DBDSN != "" {
+		t.Errorf("got driver %q dsn %q, want no database", cfg.DBDriver, cfg.DBDSN)
+	}
+}
+
+func TestLoadMissingDatabaseName(t *testing.T) {
+	clearEnv(t)
+	t.Setenv("DB_DRIVER", "mysql")
+
+	if _, err := Load(); err == nil {
+		t.Error("Load() without DB_DSN or DB_NAME for mysql succeeded, want error")
+	}
+}
+
+func TestLoadFromEnv(t *testing.T) {
+	clearEnv(t)
+	t.Setenv("PORT", "9000")
+	t.Setenv("DEBUG", "true")
+	t.Setenv("RATE_LIMIT", "5")
+	t.Setenv("DB_DRIVER", "sqlite")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Port != "9000" || !cfg.Debug || cfg.RateLimit != 5 {
+		t.Errorf("got port %q debug %t rate limit %d", cfg.Port, cfg.Debug, cfg.RateLimit)
+	}
+	if cfg.DBDSN != ":memory:" {
+		t.Errorf("DBDSN = %q, want :memory: for sqlite", cfg.DBDSN)
+	}
+}
+
+func TestLoadTimeouts(t *testing.T) {
+	clearEnv(t)
+	t.Setenv("DB_DRIVER", "sqlite")
+	t.Setenv("READ_TIMEOUT", "30s")
+	t.Setenv("WRITE_TIMEOUT", "1ms")
+	t.Setenv("IDLE_TIMEOUT", "1h")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.ReadTimeout != 30*time.Second {
+		t.Errorf("ReadTimeout = %s, want 30s", cfg.ReadTimeout)
+	}
+	if cfg.WriteTimeout != time.Second {
+		t.Errorf("WriteTimeout = %s, want it clamped to 1s", cfg.WriteTimeout)
+	}
+	if cfg.IdleTimeout != 10*time.Minute {
+		t.Errorf("IdleTimeout = %s, want it clamped to 10m", cfg.IdleTimeout)
+	}
+}
+
+func TestLoadSlowThresholdZeroDisables(t *testing.T) {
+	clearEnv(t)
+	t.Setenv("DB_DRIVER", "sqlite")
+	t.Setenv("DB_SLOW_THRESHOLD", "0")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.DBSlowThreshold != 0 {
+		t.Errorf("DBSlowThreshold = %s, want 0", cfg.DBSlowThreshold)
+	}
+}
+
+func TestLoadFeatures(t *testing.T) {
+	clearEnv(t)
+	t.Setenv("DB_DRIVER", "sqlite")
+	t.Setenv("FEATURE_REGISTRATION", "true")
+	t.Setenv("FEATURE_MAGIC_LINK", "0")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if !cfg.Features.Enabled("registration") || cfg.Features.Enabled("magic_link") || len(cfg.Features) != 2 {
+		t.Errorf("Features = %v, want registration on and magic_link off", cfg.Features)
+	}
+}
+
+func TestLoadSecurityHeaders(t *testing.T) {
+	clearEnv(t)
+	t.Setenv("DB_DRIVER", "sqlite")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.FrameOptions != "DENY" || cfg.ReferrerPolicy != "no-referrer" {
+		t.Errorf("defaults = %q, %q, want DENY, no-referrer", cfg.FrameOptions, cfg.ReferrerPolicy)
+	}
+
+	t.Setenv("X_FRAME_OPTIONS", "")
+	t.Setenv("REFERRER_POLICY", "same-origin")
+	if cfg, err = Load(); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.FrameOptions != "" || cfg.ReferrerPolicy != "same-origin" {
+		t.Errorf("got %q, %q, want frame options off and same-origin", cfg.FrameOptions, cfg.ReferrerPolicy)
+	}
+}
+
+func TestLoadProxy(t *testing.T) {
+	clearEnv(t)
+	t.Setenv("PROXY_HEADER", "X-Real-IP")
+
+	if _, err := Load(); err == nil {
+		t.Error("Load() with PROXY_HEADER but no TRUSTED_PROXIES succeeded, want error")
+	}
+
+	t.Setenv("TRUSTED_PROXIES", " 10.0.0.1, 172.16.0.0/12")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.ProxyHeader != "X-Real-IP" || strings.Join(cfg.TrustedProxies, ",") != "10.0.0.1,172.16.0.0/12" {
+		t.Errorf("got header %q proxies %v", cfg.ProxyHeader, cfg.TrustedProxies)
+	}
+
+	t.Setenv("TRUSTED_PROXIES", "proxy.internal")
+	if _, err := Load(); err == nil {
+		t.Error("Load() with a hostname in TRUSTED_PROXIES succeeded, want error")
+	}
+}
+
+func TestLoadSMTP(t *testing.T) {
+	clearEnv(t)
+	t.Setenv("DB_DRIVER", "sqlite")
+	t.Setenv("SMTP_HOST", "smtp.example.com")
+
+	if _, err := Load(); err == nil {
+		t.Error("Load() with SMTP_HOST but no SMTP_FROM succeeded, want error")
+	}
+
+	t.Setenv("SMTP_FROM", "noreply@example.com")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.SMTPHost != "smtp.example.com" || cfg.SMTPPort != "587" || cfg.SMTPFrom != "noreply@example.com" {
+		t.Errorf("got host %q port %q from %q", cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPFrom)
+	}
+}
+
+func TestParseOrigins(t *testing.T) {
+	tests := []struct {
+		raw     string
+		want    []string
+		wantErr bool
+	}{
+		{"", nil, false},
+		{"*", nil, false},
+		{" https://app.example.com/ , http://localhost:3000", []string{"https://app.example.com", "http://localhost:3000"}, false},
+		{"*,https://app.example.com", nil, true},
+		{"app.example.com", nil, true},
+		{"ftp://app.example.com", nil, true},
+		{"https://app.example.com/path", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			got, err := parseOrigins(tt.raw)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseOrigins(%q) error = %v, want error %t", tt.raw, err, tt.wantErr)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("parseOrigins(%q) = %v, want %v", tt.raw, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadRejectsBadValues(t *testing.T) {
+	tests := []struct {
+		key   string
+		value string
+	}{
+		{"DEBUG", "sometimes"},
+		{"RATE_LIMIT", "lots"},
+		{"RATE_LIMIT", "0"},
+		{"READ_TIMEOUT", "10"},
+		{"DB_DRIVER", "oracle"},
+		{"DB_LOG_LEVEL", "loud"},
+		{"FEATURE_REGISTRATION", "yes"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key+"="+tt.value, func(t *testing.T) {
+			clearEnv(t)
+			t.Setenv("DB_NAME", "gox")
+			t.Setenv(tt.key, tt.value)
+
+			if _, err := Load(); err == nil {
+				t.Errorf("Load() with %s=%q succeeded, want error", tt.key, tt.value)
+			}
+		})
+	}
+}
+
+func TestGetDurationClamps(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"", 10 * time.Second},
+		{"30s", 30 * time.Second},
+		{"1ms", time.Second},
+		{"1h", 5 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			t.Setenv("TEST_DURATION", tt.value)
+
+			got, err := getDuration("TEST_DURATION", 10*time.Second, time.Second, 5*time.Minute)
+			if err != nil {
+				t.Fatalf("getDuration() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("getDuration(%q) = %s, want %s", tt.value, got, tt.want)
+			}
+		})
+	}
+}
@@ -1,17 +1,42 @@
 package connectdb
 
 import (
+	"fmt"
+	"strings"
+
 	"gorm.io/driver/mysql"
+	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
 )
 
-func ConnectDB() (*gorm.DB, error) {
-	dsn := "user:password@tcp(127.0.0.1:3306)/dbname?charset=utf8mb4&parseTime=True&loc=Local"
-	// Replace user, password, dbname, and connection details with your actual MySQL configuration.
+// ConnectDB opens the database for driver ("mysql" or "sqlite"). For sqlite,
+// dsn is the file path or ":memory:". The sqlite driver needs cgo, a
+// CGO_ENABLED=0 build fails when it opens.
+func ConnectDB(driver, dsn string, gormLogger logger.Interface) (*gorm.DB, error) {
+	var dialector gorm.Dialector
+
+	switch driver {
+	case "mysql":
+		dialector = mysql.Open(dsn)
+	case "sqlite":
+		dialector = sqlite.Open(dsn)
+	default:
+		return nil, fmt.Errorf("connectdb: unsupported driver %q", driver)
+	}
 
-	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{})
+	db, err := gorm.Open(dialector, &gorm.Config{Logger: gormLogger})
 	if err != nil {
 		return nil, err
 	}
+
+	// every pooled connection to an in-memory sqlite gets its own empty db
+	if driver == "sqlite" && (strings.Contains(dsn, ":memory:") || strings.Contains(dsn, "mode=memory")) {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return nil, err
+		}
+		sqlDB.SetMaxOpenConns(1)
+	}
 	return db, nil
 }
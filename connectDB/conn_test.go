@@ -0,0 +1,78 @@
+package connectdb
+
+import (
+	"path/filepath"
+	"testing"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type testUser struct {
+	ID   uint
+	Name string
+}
+
+// openTestDB opens a fresh in-memory sqlite database with testUser migrated.
+func openTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := ConnectDB("sqlite", ":memory:", logger.Discard)
+	if err != nil {
+		t.Fatalf("ConnectDB() error = %v", err)
+	}
+	if err := db.AutoMigrate(&testUser{}); err != nil {
+		t.Fatalf("AutoMigrate() error = %v", err)
+	}
+	return db
+}
+
+func TestConnectDBSqliteRoundTrip(t *testing.T) {
+	db := openTestDB(t)
+
+	if err := db.Create(&testUser{Name: "alice"}).Error; err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	var got testUser
+	if err := db.First(&got, "name = ?", "alice").Error; err != nil {
+		t.Fatalf("First() error = %v", err)
+	}
+	if got.ID == 0 || got.Name != "alice" {
+		t.Errorf("got %+v, want alice with an id", got)
+	}
+}
+
+func TestConnectDBUnsupportedDriver(t *testing.T) {
+	if _, err := ConnectDB("oracle", "", logger.Discard); err == nil {
+		t.Error("ConnectDB(oracle) succeeded, want error")
+	}
+}
+
+func TestConnectDBMemorySingleConnection(t *testing.T) {
+	tests := []struct {
+		dsn      string
+		wantOpen int
+	}{
+		// each pooled connection to :memory: would be a new, empty db
+		{":memory:", 1},
+		{"file::memory:?cache=shared", 1},
+		{filepath.Join(t.TempDir(), "gox.db"), 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dsn, func(t *testing.T) {
+			db, err := ConnectDB("sqlite", tt.dsn, logger.Discard)
+			if err != nil {
+				t.Fatalf("ConnectDB() error = %v", err)
+			}
+			sqlDB, err := db.DB()
+			if err != nil {
+				t.Fatal(err)
+			}
+			t.Cleanup(func() { sqlDB.Close() })
+			if got := sqlDB.Stats().MaxOpenConnections; got != tt.wantOpen {
+				t.Errorf("MaxOpenConnections = %d, want %d", got, tt.wantOpen)
+			}
+		})
+	}
+}
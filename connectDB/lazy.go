@@ -0,0 +1,77 @@
+package connectdb
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Conn holds a database that may not be reachable at startup. DB retries
+// open until it succeeds, after which database/sql handles reconnects.
+// Only one open runs at a time and a failed one isn't retried for the
+// backoff, so callers can't pile up dials against a dead host.
+type Conn struct {
+	open    func() (*gorm.DB, error)
+	backoff time.Duration
+
+	mu      sync.Mutex
+	db      *gorm.DB
+	err     error
+	retryAt time.Time
+	// closed when the running open finishes, nil while none is running
+	opening chan struct{}
+}
+
+func NewConn(open func() (*gorm.DB, error)) *Conn {
+	return &Conn{open: open, backoff: 5 * time.Second}
+}
+
+// DB returns the database, opening it if needed. It gives up when ctx is
+// done, leaving the open running for the next caller.
+func (c *Conn) DB(ctx context.Context) (*gorm.DB, error) {
+	c.mu.Lock()
+	if c.db != nil {
+		db := c.db
+		c.mu.Unlock()
+		return db, nil
+	}
+	if c.opening == nil {
+		if time.Now().Before(c.retryAt) {
+			err := c.err
+			c.mu.Unlock()
+			return nil, err
+		}
+		c.opening = make(chan struct{})
+		go c.connect(c.opening)
+	}
+	opening := c.opening
+	c.mu.Unlock()
+
+	select {
+	case <-opening:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.db != nil {
+		return c.db, nil
+	}
+	return nil, c.err
+}
+
+func (c *Conn) connect(done chan struct{}) {
+	db, err := c.open()
+
+	c.mu.Lock()
+	c.db, c.err = db, err
+	if err != nil {
+		c.retryAt = time.Now().Add(c.backoff)
+	}
+	c.opening = nil
+	c.mu.Unlock()
+	close(done)
+}
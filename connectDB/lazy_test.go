@@ -0,0 +1,87 @@
+package connectdb
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestConnRetriesUntilOpen(t *testing.T) {
+	attempts := 0
+	conn := NewConn(func() (*gorm.DB, error) {
+		attempts++
+		if attempts == 1 {
+			return nil, errors.New("connection refused")
+		}
+		return ConnectDB("sqlite", ":memory:", logger.Discard)
+	})
+	conn.backoff = 0
+
+	if _, err := conn.DB(context.Background()); err == nil {
+		t.Fatal("first DB() succeeded, want error")
+	}
+	db, err := conn.DB(context.Background())
+	if err != nil || db == nil {
+		t.Fatalf("second DB() = %v, %v, want a db", db, err)
+	}
+	if again, _ := conn.DB(context.Background()); again != db || attempts != 2 {
+		t.Errorf("DB() reopened after success, attempts = %d", attempts)
+	}
+}
+
+func TestConnBacksOffAfterFailure(t *testing.T) {
+	attempts := 0
+	conn := NewConn(func() (*gorm.DB, error) {
+		attempts++
+		return nil, errors.New("connection refused")
+	})
+
+	for i := 0; i < 3; i++ {
+		if _, err := conn.DB(context.Background()); err == nil {
+			t.Fatal("DB() succeeded, want error")
+		}
+	}
+	if attempts != 1 {
+		t.Errorf("attempts = %d within the backoff, want 1", attempts)
+	}
+}
+
+func TestConnHonoursContext(t *testing.T) {
+	var attempts atomic.Int32
+	release := make(chan struct{})
+	conn := NewConn(func() (*gorm.DB, error) {
+		attempts.Add(1)
+		<-release
+		return nil, errors.New("connection timed out")
+	})
+	defer close(release)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+			defer cancel()
+
+			start := time.Now()
+			if _, err := conn.DB(ctx); !errors.Is(err, context.DeadlineExceeded) {
+				t.Errorf("DB() error = %v, want deadline exceeded", err)
+			}
+			if elapsed := time.Since(start); elapsed > time.Second {
+				t.Errorf("DB() took %s, want it bounded by ctx", elapsed)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n := attempts.Load(); n != 1 {
+		t.Errorf("attempts = %d for concurrent callers, want 1", n)
+	}
+}
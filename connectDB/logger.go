@@ -0,0 +1,19 @@
+package connectdb
+
+import (
+	"log"
+	"time"
+
+	"gorm.io/gorm/logger"
+)
+
+// NewLogger routes GORM logs through the standard logger. Queries slower
+// than slowThreshold are logged as warnings and record-not-found errors
+// are dropped.
+func NewLogger(slowThreshold time.Duration, level logger.LogLevel) logger.Interface {
+	return logger.New(log.Default(), logger.Config{
+		SlowThreshold:             slowThreshold,
+		LogLevel:                  level,
+		IgnoreRecordNotFoundError: true,
+	})
+}
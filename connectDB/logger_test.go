@@ -0,0 +1,65 @@
+package connectdb
+
+import (
+	"GO-X/internal/testlog"
+	"strings"
+	"testing"
+	"time"
+
+	"gorm.io/gorm/logger"
+)
+
+func TestNewLoggerIsInstalled(t *testing.T) {
+	l := NewLogger(time.Hour, logger.Warn)
+	db, err := ConnectDB("sqlite", ":memory:", l)
+	if err != nil {
+		t.Fatalf("ConnectDB() error = %v", err)
+	}
+	if db.Logger != l {
+		t.Error("ConnectDB did not install the given logger")
+	}
+}
+
+func TestNewLoggerSlowQueries(t *testing.T) {
+	tests := []struct {
+		name      string
+		threshold time.Duration
+		wantSlow  bool
+	}{
+		{"under threshold", time.Hour, false},
+		{"disabled", 0, false},
+		{"over threshold", time.Nanosecond, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, err := ConnectDB("sqlite", ":memory:", NewLogger(tt.threshold, logger.Warn))
+			if err != nil {
+				t.Fatalf("ConnectDB() error = %v", err)
+			}
+			buf := testlog.Capture(t)
+
+			db.Exec("SELECT 1")
+
+			if got := strings.Contains(buf.String(), "SLOW SQL"); got != tt.wantSlow {
+				t.Errorf("flagged slow = %t, want %t; log: %s", got, tt.wantSlow, buf.String())
+			}
+		})
+	}
+}
+
+func TestNewLoggerIgnoresRecordNotFound(t *testing.T) {
+	db, err := ConnectDB("sqlite", ":memory:", NewLogger(time.Hour, logger.Warn))
+	if err != nil {
+		t.Fatalf("ConnectDB() error = %v", err)
+	}
+	db.AutoMigrate(&testUser{})
+	buf := testlog.Capture(t)
+
+	var u testUser
+	db.First(&u)
+
+	if buf.Len() != 0 {
+		t.Errorf("record not found was logged: %s", buf.String())
+	}
+}
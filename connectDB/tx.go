@@ -0,0 +1,12 @@
+package connectdb
+
+import (
+	"gorm.io/gorm"
+)
+
+// WithTransaction runs fn inside a transaction. It commits when fn returns
+// nil and rolls back on an error or a panic, re-panicking afterwards.
+// This is just db.Transaction, kept as the one entry point the handlers use.
+func WithTransaction(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+	return db.Transaction(fn)
+}
@@ -0,0 +1,69 @@
+package connectdb
+
+import (
+	"errors"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+func countUsers(t *testing.T, db *gorm.DB) int64 {
+	t.Helper()
+	var n int64
+	if err := db.Model(&testUser{}).Count(&n).Error; err != nil {
+		t.Fatalf("Count() error = %v", err)
+	}
+	return n
+}
+
+func TestWithTransactionCommits(t *testing.T) {
+	db := openTestDB(t)
+
+	err := WithTransaction(db, func(tx *gorm.DB) error {
+		return tx.Create(&testUser{Name: "alice"}).Error
+	})
+	if err != nil {
+		t.Fatalf("WithTransaction() error = %v", err)
+	}
+	if n := countUsers(t, db); n != 1 {
+		t.Errorf("rows = %d, want 1", n)
+	}
+}
+
+func TestWithTransactionRollsBackOnError(t *testing.T) {
+	db := openTestDB(t)
+	wantErr := errors.New("boom")
+
+	err := WithTransaction(db, func(tx *gorm.DB) error {
+		if err := tx.Create(&testUser{Name: "alice"}).Error; err != nil {
+			return err
+		}
+		return wantErr
+	})
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("WithTransaction() error = %v, want %v", err, wantErr)
+	}
+	if n := countUsers(t, db); n != 0 {
+		t.Errorf("rows = %d, want 0", n)
+	}
+}
+
+func TestWithTransactionRollsBackOnPanic(t *testing.T) {
+	db := openTestDB(t)
+
+	func() {
+		defer func() {
+			if r := recover(); r != "boom" {
+				t.Errorf("recovered %v, want re-panic with boom", r)
+			}
+		}()
+		WithTransaction(db, func(tx *gorm.DB) error {
+			tx.Create(&testUser{Name: "alice"})
+			panic("boom")
+		})
+	}()
+
+	if n := countUsers(t, db); n != 0 {
+		t.Errorf("rows = %d, want 0", n)
+	}
+}
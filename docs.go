@@ -0,0 +1,95 @@
+package main
+
+import (
+	"GO-X/openapi"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// openapi 3 document for the routes registered in main
+func openAPISpec() fiber.Map {
+	jsonContent := func(schema interface{}) fiber.Map {
+		return fiber.Map{"application/json": fiber.Map{"schema": schema}}
+	}
+
+	return fiber.Map{
+		"openapi": "3.0.3",
+		"info": fiber.Map{
+			"title":   "GO-X",
+			"version": "1.0.0",
+		},
+		"paths": fiber.Map{
+			"/": fiber.Map{
+				"get": fiber.Map{
+					"summary":   "Root, always answers 400",
+					"responses": fiber.Map{"400": fiber.Map{"description": "Bad Request"}},
+				},
+			},
+			"/uuid": fiber.Map{
+				"get": fiber.Map{
+					"summary": "Generate a random UUID",
+					"responses": fiber.Map{"200": fiber.Map{
+						"description": "UUID string",
+						"content":     fiber.Map{"text/plain": fiber.Map{"schema": openapi.Schema("")}},
+					}},
+				},
+			},
+			"/users": fiber.Map{
+				"get": fiber.Map{
+					"summary": "List users",
+					"responses": fiber.Map{"200": fiber.Map{
+						"description": "All users",
+						"content":     jsonContent(openapi.Schema([]User{})),
+					}},
+				},
+			},
+			"/users/count": fiber.Map{
+				"get": fiber.Map{
+					"summary": "Count users",
+					"responses": fiber.Map{"200": fiber.Map{
+						"description": "Number of users",
+						"content": jsonContent(fiber.Map{
+							"type":       "object",
+							"properties": fiber.Map{"count": openapi.Schema(0)},
+						}),
+					}},
+				},
+			},
+			"/health/ready": fiber.Map{
+				"get": fiber.Map{
+					"summary": "Readiness of the service and its dependencies",
+					"responses": fiber.Map{
+						"200": fiber.Map{"description": "All critical dependencies are up"},
+						"503": fiber.Map{"description": "A critical dependency is down"},
+					},
+				},
+			},
+		},
+	}
+}
+
+const swaggerUI = `<!DOCTYPE html>
+<html>
+<head>
+	<title>GO-X API docs</title>
+	<link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+	<div id="swagger-ui"></div>
+	<script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js"></script>
+	<script>SwaggerUIBundle({url: "/openapi.json", dom_id: "#swagger-ui"});</script>
+</body>
+</html>`
+
+func registerDocs(app *fiber.App) {
+	spec := openAPISpec()
+
+	app.Get("/openapi.json", func(c *fiber.Ctx) error {
+		return c.JSON(spec)
+	})
+
+	app.Get("/docs", func(c *fiber.Ctx) error {
+		c.Type("html")
+		return c.SendString(swaggerUI)
+	})
+}
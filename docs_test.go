@@ -0,0 +1,55 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestOpenAPISpec(t *testing.T) {
+	app := fiber.New()
+	registerRoutes(app)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/openapi.json", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != fiber.StatusOK {
+		t.Fatalf("status = %d, want 200", resp.StatusCode)
+	}
+
+	var spec struct {
+		OpenAPI string                                `json:"openapi"`
+		Paths   map[string]map[string]json.RawMessage `json:"paths"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&spec); err != nil {
+		t.Fatalf("decode spec: %v", err)
+	}
+	if !strings.HasPrefix(spec.OpenAPI, "3.") {
+		t.Errorf("openapi = %q, want 3.x", spec.OpenAPI)
+	}
+
+	var documented, registered []string
+	for path, operations := range spec.Paths {
+		for method := range operations {
+			documented = append(documented, strings.ToUpper(method)+" "+path)
+		}
+	}
+	for _, route := range app.GetRoutes(true) {
+		// fiber adds HEAD for every GET, the docs don't describe themselves
+		if route.Method == fiber.MethodHead || route.Path == "/openapi.json" || route.Path == "/docs" {
+			continue
+		}
+		registered = append(registered, route.Method+" "+route.Path)
+	}
+	sort.Strings(documented)
+	sort.Strings(registered)
+
+	if strings.Join(documented, "\n") != strings.Join(registered, "\n") {
+		t.Errorf("spec documents\n%s\nbut the app registers\n%s", strings.Join(documented, "\n"), strings.Join(registered, "\n"))
+	}
+}
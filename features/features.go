@@ -0,0 +1,27 @@
+package features
+
+import (
+	"GO-X/apperr"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Flags maps a lower-case feature name to whether it is on, as loaded from
+// FEATURE_<NAME> by config.Load. Missing flags are off.
+type Flags map[string]bool
+
+// Enabled reports whether the feature is on, e.g. Enabled("registration")
+// for FEATURE_REGISTRATION.
+func (f Flags) Enabled(name string) bool {
+	return f[name]
+}
+
+// Require rejects requests with 403 while the feature is disabled.
+func (f Flags) Require(name string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if !f.Enabled(name) {
+			return apperr.Forbidden("This feature is disabled")
+		}
+		return c.Next()
+	}
+}
@@ -0,0 +1,38 @@
+package features
+
+import (
+	"GO-X/middleware"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestRequire(t *testing.T) {
+	tests := []struct {
+		name       string
+		flags      Flags
+		wantStatus int
+	}{
+		{"enabled", Flags{"registration": true}, fiber.StatusOK},
+		{"disabled", Flags{"registration": false}, fiber.StatusForbidden},
+		{"missing", Flags{}, fiber.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New(fiber.Config{ErrorHandler: middleware.ErrorHandler(false)})
+			app.Post("/users", tt.flags.Require("registration"), func(c *fiber.Ctx) error {
+				return c.SendStatus(fiber.StatusOK)
+			})
+
+			resp, err := app.Test(httptest.NewRequest("POST", "/users", nil))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+		})
+	}
+}
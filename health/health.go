@@ -0,0 +1,56 @@
+package health
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Check is a single dependency probe. A failing critical check makes the
+// whole service unready.
+type Check struct {
+	Name     string
+	Critical bool
+	Probe    func(ctx context.Context) error
+}
+
+type result struct {
+	Name    string `json:"name"`
+	Status  string `json:"status"`
+	Latency string `json:"latency"`
+	Error   string `json:"error,omitempty"`
+}
+
+// Ready runs every check and replies 503 if a critical one fails.
+func Ready(timeout time.Duration, checks ...Check) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx, cancel := context.WithTimeout(c.UserContext(), timeout)
+		defer cancel()
+
+		status := fiber.StatusOK
+		results := make([]result, 0, len(checks))
+		for _, check := range checks {
+			start := time.Now()
+			err := check.Probe(ctx)
+			r := result{Name: check.Name, Status: "up", Latency: time.Since(start).String()}
+			if err != nil {
+				// the cause stays in the log, callers are unauthenticated
+				log.Printf("health: %s check failed: %v", check.Name, err)
+				r.Status = "down"
+				r.Error = "unavailable"
+				if check.Critical {
+					status = fiber.StatusServiceUnavailable
+				}
+			}
+			results = append(results, r)
+		}
+
+		ready := "ready"
+		if status != fiber.StatusOK {
+			ready = "unready"
+		}
+		return c.Status(status).JSON(fiber.Map{"status": ready, "dependencies": results})
+	}
+}
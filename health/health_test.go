@@ -0,0 +1,88 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type readyBody struct {
+	Status       string `json:"status"`
+	Dependencies []struct {
+		Name    string `json:"name"`
+		Status  string `json:"status"`
+		Latency string `json:"latency"`
+		Error   string `json:"error"`
+	} `json:"dependencies"`
+}
+
+func up(ctx context.Context) error   { return nil }
+func down(ctx context.Context) error { return errors.New("connection refused") }
+
+func ready(t *testing.T, checks ...Check) (int, readyBody) {
+	t.Helper()
+	app := fiber.New()
+	app.Get("/health/ready", Ready(time.Second, checks...))
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/health/ready", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var body readyBody
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("decode body: %v", err)
+	}
+	return resp.StatusCode, body
+}
+
+func TestReadyAllHealthy(t *testing.T) {
+	status, body := ready(t,
+		Check{Name: "database", Critical: true, Probe: up},
+		Check{Name: "cache", Probe: up},
+	)
+
+	if status != fiber.StatusOK || body.Status != "ready" {
+		t.Errorf("got %d %q, want 200 ready", status, body.Status)
+	}
+	if len(body.Dependencies) != 2 {
+		t.Fatalf("dependencies = %d, want 2", len(body.Dependencies))
+	}
+	for _, dep := range body.Dependencies {
+		if dep.Status != "up" || dep.Latency == "" {
+			t.Errorf("dependency %+v, want up with a latency", dep)
+		}
+	}
+}
+
+func TestReadyCriticalDown(t *testing.T) {
+	status, body := ready(t,
+		Check{Name: "database", Critical: true, Probe: down},
+		Check{Name: "cache", Probe: up},
+	)
+
+	if status != fiber.StatusServiceUnavailable || body.Status != "unready" {
+		t.Errorf("got %d %q, want 503 unready", status, body.Status)
+	}
+	if dep := body.Dependencies[0]; dep.Status != "down" || dep.Error != "unavailable" {
+		t.Errorf("database = %+v, want down without the probe error", dep)
+	}
+}
+
+func TestReadyNonCriticalDown(t *testing.T) {
+	status, body := ready(t,
+		Check{Name: "database", Critical: true, Probe: up},
+		Check{Name: "cache", Probe: down},
+	)
+
+	if status != fiber.StatusOK || body.Status != "ready" {
+		t.Errorf("got %d %q, want 200 ready with a degraded optional dependency", status, body.Status)
+	}
+	if dep := body.Dependencies[1]; dep.Status != "down" {
+		t.Errorf("cache = %+v, want down", dep)
+	}
+}
@@ -0,0 +1,18 @@
+// Package testlog captures the standard logger in tests.
+package testlog
+
+import (
+	"bytes"
+	"log"
+	"os"
+	"testing"
+)
+
+// Capture redirects the standard logger into a buffer until the test ends.
+func Capture(t testing.TB) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	t.Cleanup(func() { log.SetOutput(os.Stderr) })
+	return &buf
+}
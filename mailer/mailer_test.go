@@ -0,0 +1,99 @@
+package mailer
+
+import (
+	"bufio"
+	"net"
+	"strings"
+	"testing"
+)
+
+// fakeSMTP accepts a single message and reports its recipient and data.
+func fakeSMTP(t *testing.T) (host, port string, received chan [2]string) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	received = make(chan [2]string, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		r := bufio.NewReader(conn)
+		reply := func(s string) { conn.Write([]byte(s + "\r\n")) }
+
+		var rcpt string
+		var data strings.Builder
+		reply("220 fake ready")
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			cmd := strings.ToUpper(strings.TrimSpace(line))
+			switch {
+			case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"), strings.HasPrefix(cmd, "MAIL"):
+				reply("250 ok")
+			case strings.HasPrefix(cmd, "RCPT TO:"):
+				rcpt = strings.Trim(strings.TrimSpace(line)[len("RCPT TO:"):], "<>")
+				reply("250 ok")
+			case cmd == "DATA":
+				reply("354 go ahead")
+				for {
+					l, err := r.ReadString('\n')
+					if err != nil || l == ".\r\n" {
+						break
+					}
+					data.WriteString(l)
+				}
+				reply("250 queued")
+				received <- [2]string{rcpt, data.String()}
+			case cmd == "QUIT":
+				reply("221 bye")
+				return
+			default:
+				reply("502 unknown")
+			}
+		}
+	}()
+
+	host, port, _ = net.SplitHostPort(ln.Addr().String())
+	return host, port, received
+}
+
+func TestSMTPMailerSend(t *testing.T) {
+	host, port, received := fakeSMTP(t)
+	m := New(host, port, "", "", "noreply@example.com")
+
+	if err := m.Send("alice@example.com", "Reset your password", "token: abc123"); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	got := <-received
+	if got[0] != "alice@example.com" {
+		t.Errorf("recipient = %q, want alice@example.com", got[0])
+	}
+	for _, want := range []string{"To: alice@example.com", "Subject: Reset your password", "token: abc123"} {
+		if !strings.Contains(got[1], want) {
+			t.Errorf("message missing %q:\n%s", want, got[1])
+		}
+	}
+}
+
+func TestSMTPMailerRejectsHeaderInjection(t *testing.T) {
+	m := &SMTPMailer{Host: "127.0.0.1", Port: "1", From: "noreply@example.com"}
+	if err := m.Send("alice@example.com\r\nBcc: eve@example.com", "hi", "body"); err == nil {
+		t.Error("Send() with a newline in the recipient succeeded, want error")
+	}
+}
+
+func TestNewWithoutHostLogs(t *testing.T) {
+	if _, ok := New("", "587", "", "", "").(LogMailer); !ok {
+		t.Error("New() without a host is not a LogMailer")
+	}
+}
@@ -1,10 +1,20 @@
 package main
 
 import (
+	"GO-X/apperr"
 	"GO-X/auth"
+	"GO-X/config"
+	connectdb "GO-X/connectDB"
+	"GO-X/features"
+	"GO-X/health"
+	"GO-X/mailer"
+	"GO-X/middleware"
+	"context"
+	"log"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
-	"github.com/gofiber/fiber/v2/middleware/cors"
+	"gorm.io/gorm"
 )
 
 // creat type for user
@@ -15,21 +25,123 @@ type User struct {
 
 var users []User
 
+// FEATURE_* flags, gate routes with flags.Require
+var flags features.Flags
+
+// sends account emails, logs them while SMTP_HOST is unset
+var mail mailer.Mailer
+
 func main() {
 
-	app := fiber.New()
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// * keep serving without a db, readiness reports it and retries
+	var checks []health.Check
+	if cfg.DBDriver != "" {
+		conn := connectdb.NewConn(func() (*gorm.DB, error) {
+			return connectdb.ConnectDB(cfg.DBDriver, cfg.DBDSN, connectdb.NewLogger(cfg.DBSlowThreshold, cfg.DBLogLevel))
+		})
+		go func() {
+			if _, err := conn.DB(context.Background()); err != nil {
+				log.Printf("database unavailable: %v", err)
+			}
+		}()
+		checks = append(checks, health.Check{
+			Name:     "database",
+			Critical: true,
+			Probe: func(ctx context.Context) error {
+				db, err := conn.DB(ctx)
+				if err != nil {
+					return err
+				}
+				sqlDB, err := db.DB()
+				if err != nil {
+					return err
+				}
+				return sqlDB.PingContext(ctx)
+			},
+		})
+	} else {
+		log.Print("no DB_* set, running without a database")
+	}
+
+	flags = cfg.Features
+	mail = mailer.New(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, cfg.SMTPFrom)
+
+	app := fiber.New(fiber.Config{
+		BodyLimit:    cfg.BodyLimit,
+		ReadTimeout:  cfg.ReadTimeout,
+		WriteTimeout: cfg.WriteTimeout,
+		IdleTimeout:  cfg.IdleTimeout,
+		ErrorHandler: middleware.ErrorHandler(cfg.Debug),
+		// * client ip for the rate limiter behind a proxy
+		ProxyHeader:             cfg.ProxyHeader,
+		EnableTrustedProxyCheck: len(cfg.TrustedProxies) > 0,
+		TrustedProxies:          cfg.TrustedProxies,
+		EnableIPValidation:      true,
+	})
+
+	// * request id, slow request log + panic recovery
+	app.Use(middleware.RequestID())
+	if cfg.SlowRequest > 0 {
+		app.Use(middleware.SlowRequests(cfg.SlowRequest))
+	}
+	app.Use(middleware.Recover())
+
+	// * redirect to https behind a tls terminating proxy
+	if cfg.ForceHTTPS {
+		app.Use(middleware.ForceHTTPS())
+	}
+
+	// * hardening headers
+	if cfg.SecurityHeaders {
+		app.Use(middleware.SecurityHeaders(cfg.FrameOptions, cfg.ReferrerPolicy, cfg.ContentSecurityPolicy))
+	}
+
+	// * log request bodies while debugging
+	if cfg.Debug {
+		app.Use(middleware.LogBody())
+	}
 
 	// * set cors
-	app.Use(cors.New(cors.Config{
-		AllowOrigins: "*",
-		AllowMethods: "GET, POST, PUT, DELETE, PATCH, HEAD",
-		AllowHeaders: "Origin, Content-Type, Accept",
-	}))
+	app.Use(middleware.Cors(cfg.CorsOrigins))
+
+	// * per ip rate limit
+	app.Use(middleware.RateLimit(cfg.RateLimit, cfg.RateWindow))
+
+	// * gzip responses
+	app.Use(middleware.Compress(cfg.CompressLevel))
+
+	// * maintenance mode
+	middleware.SetMaintenance(cfg.Maintenance)
+	app.Use(middleware.Maintenance())
+
+	// * only answer clients accepting json
+	if cfg.StrictAccept {
+		app.Use(middleware.AcceptJSON(func(c *fiber.Ctx) bool {
+			// the only routes not answering json
+			return c.Path() == "/docs" || c.Path() == "/uuid"
+		}))
+	}
+
+	// * only accept json bodies
+	app.Use(middleware.RequireJSON())
 
 	users = append(users, User{"admin", "admin"})
 
+	registerRoutes(app, checks...)
+
+	app.Listen(":" + cfg.Port)
+
+}
+
+// registerRoutes adds every route, checks back /health/ready.
+func registerRoutes(app *fiber.App, checks ...health.Check) {
 	app.Get("/", func(c *fiber.Ctx) error {
-		return c.SendStatus(fiber.StatusBadRequest)
+		return apperr.ErrBadRequest
 	})
 
 	app.Get("/uuid", func(c *fiber.Ctx) error {
@@ -40,6 +152,11 @@ func main() {
 		return c.JSON(users)
 	})
 
-	app.Listen(":8080")
+	app.Get("/users/count", func(c *fiber.Ctx) error {
+		return c.JSON(fiber.Map{"count": len(users)})
+	})
+
+	app.Get("/health/ready", health.Ready(2*time.Second, checks...))
 
+	registerDocs(app)
 }
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestUsersCount(t *testing.T) {
+	saved := users
+	t.Cleanup(func() { users = saved })
+
+	tests := []struct {
+		users []User
+		want  string
+	}{
+		{nil, `{"count":0}`},
+		{[]User{{"admin", "admin"}}, `{"count":1}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			users = tt.users
+			app := fiber.New()
+			registerRoutes(app)
+
+			resp, err := app.Test(httptest.NewRequest("GET", "/users/count", nil))
+			if err != nil {
+				t.Fatal(err)
+			}
+			body, _ := io.ReadAll(resp.Body)
+			if resp.StatusCode != fiber.StatusOK || string(body) != tt.want {
+				t.Errorf("got %d %s, want 200 %s", resp.StatusCode, body, tt.want)
+			}
+		})
+	}
+}
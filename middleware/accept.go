@@ -0,0 +1,22 @@
+package middleware
+
+import (
+	"GO-X/apperr"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// AcceptJSON replies 406 when the Accept header rules out JSON. A missing
+// header or */* is fine. next skips routes that serve something else, it
+// may be nil.
+func AcceptJSON(next func(c *fiber.Ctx) bool) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if (next != nil && next(c)) || c.Get(fiber.HeaderAccept) == "" {
+			return c.Next()
+		}
+		if c.Accepts(fiber.MIMEApplicationJSON) == "" {
+			return apperr.FromStatus(fiber.StatusNotAcceptable, "Only application/json responses are available")
+		}
+		return c.Next()
+	}
+}
@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestAcceptJSON(t *testing.T) {
+	app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler(false)})
+	app.Use(AcceptJSON(func(c *fiber.Ctx) bool {
+		return c.Path() == "/text"
+	}))
+	app.Get("/json", func(c *fiber.Ctx) error { return c.JSON(fiber.Map{"ok": true}) })
+	app.Get("/text", func(c *fiber.Ctx) error { return c.SendString("ok") })
+
+	tests := []struct {
+		path       string
+		accept     string
+		wantStatus int
+	}{
+		{"/json", "text/html", fiber.StatusNotAcceptable},
+		{"/json", "application/json", fiber.StatusOK},
+		{"/json", "*/*", fiber.StatusOK},
+		{"/json", "text/html,*/*;q=0.8", fiber.StatusOK},
+		{"/json", "", fiber.StatusOK},
+		{"/text", "text/plain", fiber.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path+" "+tt.accept, func(t *testing.T) {
+			req := httptest.NewRequest("GET", tt.path, nil)
+			if tt.accept != "" {
+				req.Header.Set(fiber.HeaderAccept, tt.accept)
+			}
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+		})
+	}
+}
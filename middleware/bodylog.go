@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"encoding/json"
+	"log"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// LogBody logs JSON request bodies with any password fields redacted.
+// Only meant for DEBUG, the body is left in place for the handlers.
+func LogBody() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		body := c.Body()
+		if len(body) > 0 && c.Is("json") {
+			var v interface{}
+			if err := json.Unmarshal(body, &v); err == nil {
+				if redacted, err := json.Marshal(redact(v)); err == nil {
+					log.Printf("%s %s [request_id=%v] body: %s", c.Method(), c.Path(), c.Locals("requestid"), redacted)
+				}
+			}
+		}
+		return c.Next()
+	}
+}
+
+func redact(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if strings.Contains(strings.ToLower(key), "password") {
+				v[key] = "[REDACTED]"
+			} else {
+				v[key] = redact(value)
+			}
+		}
+	case []interface{}:
+		for i, value := range v {
+			v[i] = redact(value)
+		}
+	}
+	return v
+}
@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"GO-X/internal/testlog"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestLogBodyRedactsPasswords(t *testing.T) {
+	logs := testlog.Capture(t)
+
+	var parsed struct {
+		Username string `json:"username"`
+		Password string `json:"password"`
+	}
+	app := fiber.New()
+	app.Use(LogBody())
+	app.Post("/users", func(c *fiber.Ctx) error {
+		return c.BodyParser(&parsed)
+	})
+
+	req := httptest.NewRequest("POST", "/users",
+		strings.NewReader(`{"username":"admin","password":"hunter2","profile":{"old_password":"swordfish"}}`))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != fiber.StatusOK {
+		t.Fatalf("status = %d, want 200", resp.StatusCode)
+	}
+
+	out := logs.String()
+	if !strings.Contains(out, "[REDACTED]") || !strings.Contains(out, `"username":"admin"`) {
+		t.Errorf("log = %q, want the body with passwords redacted", out)
+	}
+	if strings.Contains(out, "hunter2") || strings.Contains(out, "swordfish") {
+		t.Errorf("log = %q, leaks a password", out)
+	}
+	if parsed.Username != "admin" || parsed.Password != "hunter2" {
+		t.Errorf("handler parsed %+v, want the original body", parsed)
+	}
+}
@@ -0,0 +1,13 @@
+package middleware
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/compress"
+)
+
+// Compress gzips/brotlis responses for clients that accept it. level is -1
+// (disabled), 0 (default), 1 (best speed) or 2 (best compression).
+// fasthttp leaves bodies under 200 bytes uncompressed.
+func Compress(level int) fiber.Handler {
+	return compress.New(compress.Config{Level: compress.Level(level)})
+}
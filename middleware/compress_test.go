@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestCompress(t *testing.T) {
+	app := fiber.New()
+	app.Use(Compress(0))
+	app.Get("/large", func(c *fiber.Ctx) error { return c.JSON(strings.Repeat("user ", 100)) })
+	app.Get("/small", func(c *fiber.Ctx) error { return c.JSON("tiny") })
+
+	tests := []struct {
+		path         string
+		wantEncoding string
+	}{
+		{"/large", "gzip"},
+		{"/small", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			req := httptest.NewRequest("GET", tt.path, nil)
+			req.Header.Set(fiber.HeaderAcceptEncoding, "gzip")
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := resp.Header.Get(fiber.HeaderContentEncoding); got != tt.wantEncoding {
+				t.Errorf("Content-Encoding = %q, want %q", got, tt.wantEncoding)
+			}
+		})
+	}
+}
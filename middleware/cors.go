@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"log"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/cors"
+)
+
+// Cors allows every origin when origins is empty, otherwise only the listed
+// origins with credentials enabled.
+func Cors(origins []string) fiber.Handler {
+	config := cors.Config{
+		AllowOrigins: "*",
+		AllowMethods: "GET, POST, PUT, DELETE, PATCH, HEAD",
+		AllowHeaders: "Origin, Content-Type, Accept",
+	}
+	if len(origins) > 0 {
+		config.AllowOrigins = strings.Join(origins, ",")
+		config.AllowCredentials = true
+	}
+
+	log.Printf("cors: allow origins %q, credentials %t", config.AllowOrigins, config.AllowCredentials)
+	return cors.New(config)
+}
@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func preflight(t *testing.T, app *fiber.App, origin string) (allowOrigin, allowCredentials string) {
+	t.Helper()
+	req := httptest.NewRequest("OPTIONS", "/users", nil)
+	req.Header.Set(fiber.HeaderOrigin, origin)
+	req.Header.Set(fiber.HeaderAccessControlRequestMethod, "GET")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp.Header.Get(fiber.HeaderAccessControlAllowOrigin), resp.Header.Get(fiber.HeaderAccessControlAllowCredentials)
+}
+
+func TestCorsAllowlist(t *testing.T) {
+	app := fiber.New()
+	app.Use(Cors([]string{"https://app.example.com"}))
+	app.Get("/users", func(c *fiber.Ctx) error { return c.SendString("ok") })
+
+	origin, credentials := preflight(t, app, "https://app.example.com")
+	if origin != "https://app.example.com" || credentials != "true" {
+		t.Errorf("allowed origin got %q credentials %q", origin, credentials)
+	}
+
+	if origin, _ := preflight(t, app, "https://evil.example.com"); origin != "" {
+		t.Errorf("disallowed origin got Access-Control-Allow-Origin %q", origin)
+	}
+}
+
+func TestCorsAllowAll(t *testing.T) {
+	app := fiber.New()
+	app.Use(Cors(nil))
+	app.Get("/users", func(c *fiber.Ctx) error { return c.SendString("ok") })
+
+	origin, credentials := preflight(t, app, "https://anywhere.example.com")
+	if origin != "*" || credentials != "" {
+		t.Errorf("got origin %q credentials %q, want * without credentials", origin, credentials)
+	}
+}
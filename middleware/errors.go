@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"GO-X/apperr"
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ErrorHandler replies with the JSON error and code for *apperr.Error and
+// *fiber.Error. Anything else (panics included) becomes a generic 500
+// unless debug is on.
+func ErrorHandler(debug bool) fiber.ErrorHandler {
+	return func(c *fiber.Ctx, err error) error {
+		var appErr *apperr.Error
+		var fiberErr *fiber.Error
+
+		switch {
+		case errors.As(err, &appErr):
+		case errors.As(err, &fiberErr):
+			appErr = apperr.FromStatus(fiberErr.Code, fiberErr.Message)
+		case debug:
+			appErr = apperr.Internal(err.Error())
+		default:
+			appErr = apperr.ErrInternal
+		}
+
+		return c.Status(appErr.Status).JSON(fiber.Map{"error": appErr.Message, "code": appErr.Code})
+	}
+}
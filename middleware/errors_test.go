@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"GO-X/apperr"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type errorBody struct {
+	Error string `json:"error"`
+	Code  string `json:"code"`
+}
+
+func TestErrorHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		debug      bool
+		wantStatus int
+		wantCode   string
+		wantError  string
+	}{
+		{"not found", apperr.NotFound("user not found"), false, 404, apperr.CodeNotFound, "user not found"},
+		{"validation", apperr.Validation("bad email"), false, 400, apperr.CodeValidation, "bad email"},
+		{"unauthorized", apperr.ErrUnauthorized, false, 401, apperr.CodeUnauthorized, "Unauthorized"},
+		{"forbidden", apperr.ErrForbidden, false, 403, apperr.CodeForbidden, "Forbidden"},
+		{"conflict", apperr.ErrConflict, false, 409, apperr.CodeConflict, "Conflict"},
+		{"unavailable", apperr.ErrUnavailable, false, 503, apperr.CodeUnavailable, "Service Unavailable"},
+		{"wrapped apperr", fmt.Errorf("lookup: %w", apperr.ErrNotFound), false, 404, apperr.CodeNotFound, "Not Found"},
+		{"fiber error", fiber.ErrTooManyRequests, false, 429, "TOO_MANY_REQUESTS", "Too Many Requests"},
+		{"plain error", errors.New("db exploded"), false, 500, apperr.CodeInternal, "Internal Server Error"},
+		{"plain error with debug", errors.New("db exploded"), true, 500, apperr.CodeInternal, "db exploded"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler(tt.debug)})
+			app.Get("/", func(c *fiber.Ctx) error { return tt.err })
+
+			resp, err := app.Test(httptest.NewRequest("GET", "/", nil))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+
+			var body errorBody
+			if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+				t.Fatalf("decode body: %v", err)
+			}
+			if body.Code != tt.wantCode || body.Error != tt.wantError {
+				t.Errorf("body = %+v, want code %q error %q", body, tt.wantCode, tt.wantError)
+			}
+		})
+	}
+}
+
+func TestAppErrIs(t *testing.T) {
+	if !errors.Is(apperr.NotFound("user 1"), apperr.ErrNotFound) {
+		t.Error("NotFound(...) is not ErrNotFound")
+	}
+	if errors.Is(apperr.NotFound("user 1"), apperr.ErrForbidden) {
+		t.Error("NotFound(...) matched ErrForbidden")
+	}
+}
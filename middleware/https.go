@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ForceHTTPS redirects plain http requests to https, as reported by the
+// proxy in X-Forwarded-Proto, and sets HSTS on everything else. /health
+// routes are left alone so plain http probes still work.
+func ForceHTTPS() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if strings.HasPrefix(c.Path(), "/health") {
+			return c.Next()
+		}
+
+		proto := c.Get(fiber.HeaderXForwardedProto)
+		if proto == "" {
+			proto = c.Protocol()
+		}
+		if proto == "http" {
+			// the request's own Host, c.Hostname would trust X-Forwarded-Host.
+			// RequestURI is path+query even for absolute-form request lines
+			uri := c.Request().URI()
+			return c.Redirect("https://"+string(uri.Host())+string(uri.RequestURI()), fiber.StatusMovedPermanently)
+		}
+
+		c.Set(fiber.HeaderStrictTransportSecurity, "max-age=31536000; includeSubDomains")
+		return c.Next()
+	}
+}
@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestForceHTTPS(t *testing.T) {
+	app := fiber.New()
+	app.Use(ForceHTTPS())
+	app.Get("/users", func(c *fiber.Ctx) error { return c.SendString("ok") })
+	app.Get("/health/ready", func(c *fiber.Ctx) error { return c.SendString("ok") })
+
+	tests := []struct {
+		path       string
+		proto      string
+		wantStatus int
+		wantHSTS   bool
+	}{
+		{"/users?page=2", "http", fiber.StatusMovedPermanently, false},
+		{"/users", "https", fiber.StatusOK, true},
+		{"/health/ready", "http", fiber.StatusOK, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.proto+" "+tt.path, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "http://api.example.com"+tt.path, nil)
+			req.Header.Set(fiber.HeaderXForwardedProto, tt.proto)
+			// must not end up in the redirect
+			req.Header.Set(fiber.HeaderXForwardedHost, "evil.example")
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+			if tt.wantStatus == fiber.StatusMovedPermanently {
+				if got, want := resp.Header.Get(fiber.HeaderLocation), "https://api.example.com"+tt.path; got != want {
+					t.Errorf("Location = %q, want %q", got, want)
+				}
+			}
+			if got := resp.Header.Get(fiber.HeaderStrictTransportSecurity) != ""; got != tt.wantHSTS {
+				t.Errorf("HSTS present = %t, want %t", got, tt.wantHSTS)
+			}
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package middleware
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+// RequireJSON rejects write requests carrying a non-JSON body with 415.
+func RequireJSON() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		switch c.Method() {
+		case fiber.MethodPost, fiber.MethodPut, fiber.MethodPatch:
+			if len(c.Body()) > 0 && !c.Is("json") {
+				return fiber.ErrUnsupportedMediaType
+			}
+		}
+		return c.Next()
+	}
+}
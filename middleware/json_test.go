@@ -0,0 +1,86 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestRequireJSON(t *testing.T) {
+	app := fiber.New(fiber.Config{BodyLimit: 100, ErrorHandler: ErrorHandler(false)})
+	app.Use(RequireJSON())
+	app.Post("/users", func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusCreated) })
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		wantStatus  int
+		wantCode    string
+	}{
+		{"json", "application/json", `{"username":"alice"}`, fiber.StatusCreated, ""},
+		{"json with charset", "application/json; charset=utf-8", `{}`, fiber.StatusCreated, ""},
+		{"wrong content type", "text/plain", "alice", fiber.StatusUnsupportedMediaType, "UNSUPPORTED_MEDIA_TYPE"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/users", strings.NewReader(tt.body))
+			req.Header.Set(fiber.HeaderContentType, tt.contentType)
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.StatusCode != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+			if tt.wantCode == "" {
+				return
+			}
+			var body errorBody
+			if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+				t.Fatalf("decode body: %v", err)
+			}
+			if body.Code != tt.wantCode {
+				t.Errorf("code = %q, want %q", body.Code, tt.wantCode)
+			}
+		})
+	}
+}
+
+// the body limit is enforced by fasthttp while reading the request, which
+// app.Test reports as an error, so this one goes over a real listener
+func TestBodyLimit(t *testing.T) {
+	app := fiber.New(fiber.Config{BodyLimit: 100, ErrorHandler: ErrorHandler(false), DisableStartupMessage: true})
+	app.Post("/users", func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusCreated) })
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go app.Listener(ln)
+	t.Cleanup(func() { app.Shutdown() })
+
+	body := `{"username":"` + strings.Repeat("a", 200) + `"}`
+	resp, err := http.Post("http://"+ln.Addr().String()+"/users", "application/json", strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != fiber.StatusRequestEntityTooLarge {
+		t.Errorf("status = %d, want 413", resp.StatusCode)
+	}
+	var got errorBody
+	if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+		t.Fatalf("decode body: %v", err)
+	}
+	if got.Code != "REQUEST_ENTITY_TOO_LARGE" {
+		t.Errorf("code = %q, want REQUEST_ENTITY_TOO_LARGE", got.Code)
+	}
+}
@@ -0,0 +1,88 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestRateLimit(t *testing.T) {
+	app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler(false)})
+	app.Use(RateLimit(2, time.Minute))
+	app.Get("/users", func(c *fiber.Ctx) error { return c.SendString("ok") })
+	app.Get("/health/ready", func(c *fiber.Ctx) error { return c.SendString("ok") })
+
+	get := func(path string) *http.Response {
+		t.Helper()
+		resp, err := app.Test(httptest.NewRequest("GET", path, nil))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp
+	}
+
+	for i := 0; i < 2; i++ {
+		if resp := get("/users"); resp.StatusCode != fiber.StatusOK {
+			t.Fatalf("request %d status = %d, want 200", i+1, resp.StatusCode)
+		}
+	}
+
+	resp := get("/users")
+	if resp.StatusCode != fiber.StatusTooManyRequests {
+		t.Fatalf("status past the limit = %d, want 429", resp.StatusCode)
+	}
+	if resp.Header.Get(fiber.HeaderRetryAfter) == "" {
+		t.Error("429 without a Retry-After header")
+	}
+	var body errorBody
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("decode body: %v", err)
+	}
+	if body.Code != "TOO_MANY_REQUESTS" {
+		t.Errorf("code = %q, want TOO_MANY_REQUESTS", body.Code)
+	}
+
+	for i := 0; i < 5; i++ {
+		if resp := get("/health/ready"); resp.StatusCode != fiber.StatusOK {
+			t.Fatalf("/health/ready status = %d, want it never limited", resp.StatusCode)
+		}
+	}
+}
+
+func TestRateLimitBehindProxy(t *testing.T) {
+	app := fiber.New(fiber.Config{
+		ErrorHandler:            ErrorHandler(false),
+		ProxyHeader:             "X-Real-IP",
+		EnableTrustedProxyCheck: true,
+		// app.Test connects from 0.0.0.0
+		TrustedProxies:     []string{"0.0.0.0"},
+		EnableIPValidation: true,
+	})
+	app.Use(RateLimit(1, time.Minute))
+	app.Get("/users", func(c *fiber.Ctx) error { return c.SendString("ok") })
+
+	get := func(clientIP string) int {
+		t.Helper()
+		req := httptest.NewRequest("GET", "/users", nil)
+		req.Header.Set("X-Real-IP", clientIP)
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp.StatusCode
+	}
+
+	if status := get("203.0.113.1"); status != fiber.StatusOK {
+		t.Fatalf("first client status = %d, want 200", status)
+	}
+	if status := get("203.0.113.2"); status != fiber.StatusOK {
+		t.Errorf("second client status = %d, want its own bucket", status)
+	}
+	if status := get("203.0.113.1"); status != fiber.StatusTooManyRequests {
+		t.Errorf("first client again status = %d, want 429", status)
+	}
+}
@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"GO-X/apperr"
+	"strings"
+	"sync/atomic"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+var maintenance atomic.Bool
+
+// SetMaintenance turns maintenance mode on or off at runtime.
+func SetMaintenance(on bool) {
+	maintenance.Store(on)
+}
+
+// Maintenance replies 503 to everything but /health routes while
+// maintenance mode is on. The startup state is set with SetMaintenance.
+func Maintenance() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if maintenance.Load() && !strings.HasPrefix(c.Path(), "/health") {
+			return apperr.Unavailable("Service is under maintenance, please try again later")
+		}
+		return c.Next()
+	}
+}
@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestMaintenance(t *testing.T) {
+	app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler(false)})
+	app.Use(Maintenance())
+	app.Get("/users", func(c *fiber.Ctx) error { return c.SendString("ok") })
+	app.Get("/health/ready", func(c *fiber.Ctx) error { return c.SendString("ok") })
+
+	status := func(path string) int {
+		t.Helper()
+		resp, err := app.Test(httptest.NewRequest("GET", path, nil))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp.StatusCode
+	}
+
+	SetMaintenance(true)
+	t.Cleanup(func() { SetMaintenance(false) })
+
+	if got := status("/users"); got != fiber.StatusServiceUnavailable {
+		t.Errorf("/users during maintenance = %d, want 503", got)
+	}
+	if got := status("/health/ready"); got != fiber.StatusOK {
+		t.Errorf("/health/ready during maintenance = %d, want 200", got)
+	}
+
+	// building another handler must not reset the runtime state
+	Maintenance()
+	if got := status("/users"); got != fiber.StatusServiceUnavailable {
+		t.Errorf("/users after Maintenance() = %d, want 503", got)
+	}
+
+	SetMaintenance(false)
+	if got := status("/users"); got != fiber.StatusOK {
+		t.Errorf("/users after maintenance = %d, want 200", got)
+	}
+}
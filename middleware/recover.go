@@ -0,0 +1,20 @@
+package middleware
+
+import (
+	"log"
+	"runtime/debug"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/recover"
+)
+
+// Recover turns handler panics into errors for ErrorHandler and logs the
+// stack trace along with the request id.
+func Recover() fiber.Handler {
+	return recover.New(recover.Config{
+		EnableStackTrace: true,
+		StackTraceHandler: func(c *fiber.Ctx, e interface{}) {
+			log.Printf("panic: %v [request_id=%v]\n%s", e, c.Locals("requestid"), debug.Stack())
+		},
+	})
+}
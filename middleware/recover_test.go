@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"GO-X/internal/testlog"
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestRecover(t *testing.T) {
+	tests := []struct {
+		debug     bool
+		wantError string
+	}{
+		{false, "Internal Server Error"},
+		{true, "secret detail"},
+	}
+
+	for _, tt := range tests {
+		t.Run(map[bool]string{false: "production", true: "debug"}[tt.debug], func(t *testing.T) {
+			logs := testlog.Capture(t)
+			app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler(tt.debug)})
+			app.Use(RequestID(), Recover())
+			app.Get("/panic", func(c *fiber.Ctx) error { panic("secret detail") })
+
+			resp, err := app.Test(httptest.NewRequest("GET", "/panic", nil))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.StatusCode != fiber.StatusInternalServerError {
+				t.Errorf("status = %d, want 500", resp.StatusCode)
+			}
+
+			var body errorBody
+			if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+				t.Fatalf("decode body: %v", err)
+			}
+			if body.Error != tt.wantError || body.Code != "INTERNAL_SERVER_ERROR" {
+				t.Errorf("body = %+v, want error %q", body, tt.wantError)
+			}
+
+			requestID := resp.Header.Get(fiber.HeaderXRequestID)
+			if !strings.Contains(logs.String(), "panic: secret detail [request_id="+requestID+"]") {
+				t.Errorf("panic not logged with request id %q:\n%s", requestID, logs.String())
+			}
+		})
+	}
+}
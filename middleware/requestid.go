@@ -0,0 +1,22 @@
+package middleware
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/requestid"
+	"github.com/google/uuid"
+)
+
+// RequestID reuses an upstream X-Request-ID when it is a canonical
+// (36 char, hyphenated) UUID and generates a new one otherwise.
+func RequestID() fiber.Handler {
+	handler := requestid.New()
+	return func(c *fiber.Ctx) error {
+		if id := c.Get(fiber.HeaderXRequestID); id != "" {
+			// uuid.Parse also takes urn:, braced and unhyphenated forms
+			if _, err := uuid.Parse(id); err != nil || len(id) != 36 {
+				c.Request().Header.Del(fiber.HeaderXRequestID)
+			}
+		}
+		return handler(c)
+	}
+}
@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+func requestIDFor(t *testing.T, incoming string) (header, local string) {
+	t.Helper()
+	app := fiber.New()
+	app.Use(RequestID())
+	app.Get("/", func(c *fiber.Ctx) error {
+		local, _ = c.Locals("requestid").(string)
+		return nil
+	})
+
+	req := httptest.NewRequest("GET", "/", nil)
+	if incoming != "" {
+		req.Header.Set(fiber.HeaderXRequestID, incoming)
+	}
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp.Header.Get(fiber.HeaderXRequestID), local
+}
+
+func TestRequestIDInherited(t *testing.T) {
+	incoming := "3f1c9a0e-7a43-4c1e-9a55-0d6c0c7e2b11"
+
+	header, local := requestIDFor(t, incoming)
+	if header != incoming || local != incoming {
+		t.Errorf("got header %q local %q, want %q reused", header, local, incoming)
+	}
+}
+
+func TestRequestIDGenerated(t *testing.T) {
+	for _, incoming := range []string{
+		"",
+		"not-a-uuid",
+		"urn:uuid:3f1c9a0e-7a43-4c1e-9a55-0d6c0c7e2b11",
+		"{3f1c9a0e-7a43-4c1e-9a55-0d6c0c7e2b11}",
+		"3f1c9a0e7a434c1e9a550d6c0c7e2b11",
+	} {
+		t.Run(incoming, func(t *testing.T) {
+			header, local := requestIDFor(t, incoming)
+			if header == incoming || header != local {
+				t.Errorf("got header %q local %q, want a fresh id", header, local)
+			}
+			if _, err := uuid.Parse(header); err != nil || len(header) != 36 {
+				t.Errorf("generated id %q is not a canonical uuid", header)
+			}
+		})
+	}
+}
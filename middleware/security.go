@@ -0,0 +1,23 @@
+package middleware
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+// SecurityHeaders sets nosniff plus the given frame, referrer and content
+// security policies. Empty values are left out.
+func SecurityHeaders(frameOptions, referrerPolicy, csp string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		c.Set(fiber.HeaderXContentTypeOptions, "nosniff")
+		if frameOptions != "" {
+			c.Set(fiber.HeaderXFrameOptions, frameOptions)
+		}
+		if referrerPolicy != "" {
+			c.Set(fiber.HeaderReferrerPolicy, referrerPolicy)
+		}
+		if csp != "" {
+			c.Set(fiber.HeaderContentSecurityPolicy, csp)
+		}
+		return c.Next()
+	}
+}
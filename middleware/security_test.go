@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestSecurityHeaders(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler fiber.Handler
+		want    map[string]string
+	}{
+		{
+			name:    "defaults",
+			handler: SecurityHeaders("DENY", "no-referrer", ""),
+			want: map[string]string{
+				fiber.HeaderXContentTypeOptions:   "nosniff",
+				fiber.HeaderXFrameOptions:         "DENY",
+				fiber.HeaderReferrerPolicy:        "no-referrer",
+				fiber.HeaderContentSecurityPolicy: "",
+			},
+		},
+		{
+			name:    "customised",
+			handler: SecurityHeaders("", "same-origin", "default-src 'self'"),
+			want: map[string]string{
+				fiber.HeaderXContentTypeOptions:   "nosniff",
+				fiber.HeaderXFrameOptions:         "",
+				fiber.HeaderReferrerPolicy:        "same-origin",
+				fiber.HeaderContentSecurityPolicy: "default-src 'self'",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Use(tt.handler)
+			app.Get("/", func(c *fiber.Ctx) error { return c.SendString("ok") })
+
+			resp, err := app.Test(httptest.NewRequest("GET", "/", nil))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for header, want := range tt.want {
+				if got := resp.Header.Get(header); got != want {
+					t.Errorf("%s = %q, want %q", header, got, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"GO-X/apperr"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// SlowRequests logs a warning for requests taking longer than threshold.
+func SlowRequests(threshold time.Duration) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+		err := c.Next()
+		elapsed := time.Since(start)
+		if elapsed < threshold {
+			return err
+		}
+
+		// ErrorHandler hasn't written the status yet when err is set
+		status := c.Response().StatusCode()
+		if err != nil {
+			status = fiber.StatusInternalServerError
+			var appErr *apperr.Error
+			var fiberErr *fiber.Error
+			if errors.As(err, &appErr) {
+				status = appErr.Status
+			} else if errors.As(err, &fiberErr) {
+				status = fiberErr.Code
+			}
+		}
+
+		log.Printf("warn: slow request %s %s (route %s) status %d took %s [request_id=%v]",
+			c.Method(), c.OriginalURL(), c.Route().Path, status, elapsed, c.Locals("requestid"))
+		return err
+	}
+}
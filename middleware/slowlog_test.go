@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"GO-X/apperr"
+	"GO-X/internal/testlog"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestSlowRequests(t *testing.T) {
+	const requestID = "4b1f2c3d-5e6f-4a7b-8c9d-0e1f2a3b4c5d"
+
+	tests := []struct {
+		name    string
+		path    string
+		wantLog []string
+	}{
+		{"slow", "/slow/42", []string{"GET /slow/42", "(route /slow/:id)", "status 200", "[request_id=" + requestID + "]"}},
+		{"slow error", "/fail/42", []string{"GET /fail/42", "(route /fail/:id)", "status 404", "[request_id=" + requestID + "]"}},
+		{"fast", "/fast", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logs := testlog.Capture(t)
+			app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler(false)})
+			app.Use(RequestID(), SlowRequests(20*time.Millisecond))
+			app.Get("/slow/:id", func(c *fiber.Ctx) error {
+				time.Sleep(30 * time.Millisecond)
+				return c.SendString("ok")
+			})
+			app.Get("/fail/:id", func(c *fiber.Ctx) error {
+				time.Sleep(30 * time.Millisecond)
+				return apperr.NotFound("user not found")
+			})
+			app.Get("/fast", func(c *fiber.Ctx) error { return c.SendString("ok") })
+
+			req := httptest.NewRequest("GET", tt.path, nil)
+			req.Header.Set(fiber.HeaderXRequestID, requestID)
+			if _, err := app.Test(req); err != nil {
+				t.Fatal(err)
+			}
+
+			out := logs.String()
+			if tt.wantLog == nil {
+				if out != "" {
+					t.Errorf("log = %q, want nothing for a fast request", out)
+				}
+				return
+			}
+			for _, want := range tt.wantLog {
+				if !strings.Contains(out, want) {
+					t.Errorf("log = %q, want it to contain %q", out, want)
+				}
+			}
+		})
+	}
+}
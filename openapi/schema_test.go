@@ -0,0 +1,55 @@
+package openapi
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+type model struct {
+	ID        uint      `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type account struct {
+	model
+	// wins over a promoted field of the same name
+	ID      string   `json:"id"`
+	Email   string   `json:"email,omitempty"`
+	Secret  string   `json:"-"`
+	Parent  *account `json:"parent"`
+	private string
+}
+
+func TestSchema(t *testing.T) {
+	got, _ := json.Marshal(Schema(account{}))
+	want := `{"properties":{` +
+		`"created_at":{"format":"date-time","type":"string"},` +
+		`"email":{"type":"string"},` +
+		`"id":{"type":"string"},` +
+		`"parent":{"type":"object"}` +
+		`},"type":"object"}`
+	if string(got) != want {
+		t.Errorf("Schema(account{}) =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestSchemaScalars(t *testing.T) {
+	tests := []struct {
+		v    interface{}
+		want string
+	}{
+		{"", `{"type":"string"}`},
+		{0, `{"type":"integer"}`},
+		{1.5, `{"type":"number"}`},
+		{true, `{"type":"boolean"}`},
+		{time.Time{}, `{"format":"date-time","type":"string"}`},
+		{[]uint{}, `{"items":{"type":"integer"},"type":"array"}`},
+	}
+
+	for _, tt := range tests {
+		if got, _ := json.Marshal(Schema(tt.v)); string(got) != tt.want {
+			t.Errorf("Schema(%T) = %s, want %s", tt.v, got, tt.want)
+		}
+	}
+}